@@ -19,6 +19,8 @@ package badger
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"fmt"
 	"sync"
 	"time"
 
@@ -61,8 +63,206 @@ type Stream struct {
 	// single goroutine, i.e. logic within Send method can expect single threaded execution.
 	Send func(*pb.KVList) error
 
+	// Since, if set, resumes a previous Stream from the checkpoint token it last handed to
+	// EmitCheckpoint. keyRanges that finished entirely before the token's boundary are skipped,
+	// and the one keyRange straddling the boundary is clipped so iteration picks up right after
+	// it instead of re-scanning from the start. This lets a crashed backup or replication
+	// consumer resume without re-reading the whole DB.
+	Since []byte
+
+	// EmitCheckpoint, if set, is invoked every time Stream learns that every keyRange to the
+	// left of some boundary has been fully sent. Because ranges are processed concurrently and
+	// can finish out of order, the checkpoint can only ever advance over an unbroken prefix of
+	// completed ranges. The token passed to EmitCheckpoint can be persisted and handed back as
+	// Since on a future Stream to resume from this point.
+	EmitCheckpoint func(token []byte) error
+
+	// MaxBytesPerSec, if positive, caps the number of bytes per second Stream pushes onto
+	// kvChan and hands to Send. Useful for keeping backups and snapshot moves from saturating
+	// the network or disk of a live cluster. When the limit is hit, Stream blocks rather than
+	// dropping data; cancel ctx passed to Orchestrate to unblock it.
+	MaxBytesPerSec int64
+
+	// MaxKeysPerSec, if positive, caps the number of keys per second in the same way as
+	// MaxBytesPerSec.
+	MaxKeysPerSec int64
+
+	// SinceVersion, if positive, restricts Stream to key-values committed strictly above this
+	// version; every keyRange is still scanned, but produceKVs filters out every key-value whose
+	// version is at or below the watermark. Combined with Follow, this turns Stream into a
+	// change-data-capture feed suitable for driving downstream indexers or Kafka-style consumers.
+	SinceVersion uint64
+
+	// Follow, if true, keeps Stream running after the SinceVersion snapshot above has been fully
+	// sent: newly committed entries are turned into pb.KVLists and pushed through the same Send,
+	// preserving the single-threaded Send invariant. In Follow mode, Orchestrate returns once
+	// ctx is done, or earlier with the error if Send or the subscription fails.
+	Follow bool
+
+	// Shard, if set, routes each outgoing key to one of NumShards independent Send pipelines,
+	// instead of the single goroutine Stream otherwise uses. This lets a downstream that fans
+	// out to N Kafka partitions / N gRPC streams / N files saturate the network. Leave nil (the
+	// default) to keep every key on the single pipeline regardless of NumShards.
+	Shard func(key []byte) int
+
+	// NumShards is the number of parallel Send pipelines Shard routes into. Defaults to 1, which
+	// preserves the original single-goroutine behavior and makes Shard irrelevant.
+	NumShards int
+
 	rangeCh chan keyRange
-	kvChan  chan *pb.KVList
+	kvChans []chan *kvBatch
+
+	// monitors holds one Monitor per shard (a single entry for the default NumShards of 1).
+	// Monitor.sample expects a monotonically increasing *global* total, so each shard must feed
+	// its own local running total into its own Monitor; Status aggregates across all of them.
+	monitors    []*Monitor
+	byteLimiter *rateLimiter
+	keyLimiter  *rateLimiter
+
+	// rightBounds holds the right boundary of every keyRange produceRanges emits, in
+	// lexicographic order. completed[i] is set once the keyRange at rightBounds[i] has been
+	// fully iterated (or was skipped outright because Since already covered it). checkpointCur
+	// is the index of the next keyRange the checkpoint cursor hasn't yet accounted for.
+	// boundaryIdx maps a keyRange's right boundary back to its index in the two slices above.
+	// rangeShardsPending counts, for a range that produced data, how many distinct shards still
+	// owe a completed Send before that range -- not just one shard's slice of it -- can be
+	// marked complete; see registerRangeShards and markRangeDone.
+	checkpointMu       sync.Mutex
+	rightBounds        [][]byte
+	completed          []bool
+	checkpointCur      int
+	boundaryIdx        map[string]int
+	rangeShardsPending map[string]int
+}
+
+// checkpoint is the opaque token format handed to EmitCheckpoint and accepted back via Since. It
+// pairs the right boundary of the last fully-completed keyRange with the readTs the Stream ran
+// at, so a resumed Stream reads a consistent snapshot.
+type checkpoint struct {
+	boundary []byte
+	readTs   uint64
+}
+
+func (c checkpoint) encode() []byte {
+	buf := make([]byte, 8+len(c.boundary))
+	binary.BigEndian.PutUint64(buf[:8], c.readTs)
+	copy(buf[8:], c.boundary)
+	return buf
+}
+
+func decodeCheckpoint(token []byte) checkpoint {
+	if len(token) < 8 {
+		return checkpoint{}
+	}
+	return checkpoint{
+		readTs:   binary.BigEndian.Uint64(token[:8]),
+		boundary: y.SafeCopy(nil, token[8:]),
+	}
+}
+
+// rateLimiter is a simple token-bucket governor used to cap Stream's bandwidth and throughput.
+// allow blocks -- honoring ctx.Done() -- until n tokens are available, then deducts them. A
+// request for more than perSec tokens goes through once the bucket is full, leaving the bucket
+// in debt that later requests wait out.
+type rateLimiter struct {
+	mu       sync.Mutex
+	perSec   float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newRateLimiter(perSec float64) *rateLimiter {
+	return &rateLimiter{perSec: perSec, tokens: perSec, lastFill: time.Now()}
+}
+
+func (rl *rateLimiter) allow(ctx context.Context, n float64) error {
+	if rl == nil || n <= 0 {
+		return nil
+	}
+	for {
+		rl.mu.Lock()
+		now := time.Now()
+		rl.tokens += now.Sub(rl.lastFill).Seconds() * rl.perSec
+		if rl.tokens > rl.perSec {
+			rl.tokens = rl.perSec
+		}
+		rl.lastFill = now
+		if rl.tokens >= n || rl.tokens >= rl.perSec {
+			rl.tokens -= n
+			rl.mu.Unlock()
+			return nil
+		}
+		rl.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// throttle blocks until sending numBytes across numKeys keys is permitted by MaxBytesPerSec and
+// MaxKeysPerSec, honoring ctx cancellation. It is a no-op for any limit left unconfigured.
+func (st *Stream) throttle(ctx context.Context, numBytes, numKeys int) error {
+	if err := st.byteLimiter.allow(ctx, float64(numBytes)); err != nil {
+		return err
+	}
+	if err := st.keyLimiter.allow(ctx, float64(numKeys)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Monitor tracks Stream's throughput over time via an exponentially weighted moving average,
+// borrowing the sampling idea of flow-control rate monitors. streamKVs feeds it a sample
+// after every successful Send, and Stream.Status exposes it to callers driving progress bars or
+// alerting off a running Stream.
+type Monitor struct {
+	mu       sync.Mutex
+	lastTime time.Time
+	lastSent uint64
+	curRate  float64
+	avgRate  float64
+}
+
+func newMonitor() *Monitor {
+	return &Monitor{lastTime: time.Now()}
+}
+
+func (m *Monitor) sample(totalSent uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	dur := now.Sub(m.lastTime).Seconds()
+	if dur <= 0 {
+		return
+	}
+	const alpha = 0.2 // Smoothing factor for the EMA.
+	inst := float64(totalSent-m.lastSent) / dur
+	if m.avgRate == 0 {
+		m.avgRate = inst
+	} else {
+		m.avgRate = alpha*inst + (1-alpha)*m.avgRate
+	}
+	m.curRate = inst
+	m.lastSent = totalSent
+	m.lastTime = now
+}
+
+// Status returns Stream's most recent instantaneous send rate and its EMA-smoothed average rate,
+// both in bytes/sec, along with an estimate of the time remaining. timeRem is only meaningful
+// once Stream knows its total size upfront, which it currently doesn't, so it is always zero.
+// With multiple shards, the rates reported are the sum across every shard's own Monitor.
+func (st *Stream) Status() (curRate, avgRate float64, timeRem time.Duration) {
+	for _, m := range st.monitors {
+		m.mu.Lock()
+		curRate += m.curRate
+		avgRate += m.avgRate
+		m.mu.Unlock()
+	}
+	return curRate, avgRate, 0
 }
 
 // ToList is a default implementation of KeyToList. It picks up all valid versions of the key,
@@ -107,19 +307,142 @@ func (st *Stream) ToList(key []byte, itr *Iterator) (*pb.KVList, error) {
 func (st *Stream) produceRanges(ctx context.Context) {
 	splits := st.db.KeySplits(st.Prefix)
 	start := y.SafeCopy(nil, st.Prefix)
+
+	var ranges []keyRange
 	for _, key := range splits {
-		st.rangeCh <- keyRange{left: start, right: y.SafeCopy(nil, []byte(key))}
+		ranges = append(ranges, keyRange{left: start, right: y.SafeCopy(nil, []byte(key))})
 		start = y.SafeCopy(nil, []byte(key))
 	}
 	// Edge case: prefix is empty and no splits exist. In that case, we should have at least one
 	// keyRange output.
-	st.rangeCh <- keyRange{left: start}
+	ranges = append(ranges, keyRange{left: start})
+
+	st.rightBounds = make([][]byte, len(ranges))
+	st.completed = make([]bool, len(ranges))
+	st.boundaryIdx = make(map[string]int, len(ranges))
+	st.rangeShardsPending = make(map[string]int, len(ranges))
+	for i, kr := range ranges {
+		st.rightBounds[i] = kr.right
+		st.boundaryIdx[string(kr.right)] = i
+	}
+
+	var resume checkpoint
+	if len(st.Since) > 0 {
+		resume = decodeCheckpoint(st.Since)
+	}
+
+	for _, kr := range ranges {
+		if len(resume.boundary) > 0 {
+			if len(kr.right) > 0 && bytes.Compare(kr.right, resume.boundary) <= 0 {
+				// This range was fully delivered by a prior run. Account for it in the
+				// checkpoint bookkeeping, but never hand it to produceKVs.
+				if err := st.markRangeDone(kr); err != nil {
+					Infof("Error while re-emitting checkpoint on resume: %v\n", err)
+				}
+				continue
+			}
+			if bytes.Compare(kr.left, resume.boundary) < 0 {
+				// This range straddles the resume boundary. Clip it so we pick up right where
+				// the prior run left off, instead of re-scanning from its original left edge.
+				kr.left = y.SafeCopy(nil, resume.boundary)
+			}
+		}
+		// SinceVersion is enforced item-by-item in produceKVs instead of skipping whole ranges
+		// here: doing it at range granularity would need SST-level max-version metadata that
+		// isn't exposed anywhere in this package, so every range below the watermark still goes
+		// through iteration, just filtered down to nothing.
+		st.rangeCh <- kr
+	}
 	close(st.rangeCh)
 }
 
-// produceKVs picks up ranges from rangeCh, generates KV lists and sends them to kvChan.
+// kvBatch is what produceKVs actually pushes onto a shard's kvChan. ranges lists the keyRanges
+// that became fully produced as part of this particular push; streamKVs only reports them to
+// markRangeDone once the batch carrying them has actually been handed to Send, not merely
+// enqueued here. A range can span multiple kvBatches (across shards, or across pageSize-sized
+// flushes within one shard), so ranges is only ever non-empty on the last kvBatch a given shard
+// produces for that range.
+type kvBatch struct {
+	list   *pb.KVList
+	ranges []keyRange
+}
+
+// registerRangeShards records that kr was split across n distinct shards, so markRangeDone only
+// considers kr complete once all n of them have reported a successful Send for their slice of
+// it. Must be called before any of those shards' closing batches can reach markRangeDone, i.e.
+// before produceKVs hands the closing batch to any of them.
+func (st *Stream) registerRangeShards(kr keyRange, n int) {
+	st.checkpointMu.Lock()
+	defer st.checkpointMu.Unlock()
+	st.rangeShardsPending[string(kr.right)] = n
+}
+
+// markRangeDone reports that one shard has finished sending its slice of kr (or, for a range
+// skipped outright on resume or never registered with registerRangeShards, that kr needed no
+// further shards at all). Once every shard that touched kr has reported in, it advances the
+// checkpoint cursor over any run of now-complete ranges starting where it last left off. Ranges
+// finish out of order -- each is owned by a different produceKVs/streamKVs goroutine pairing --
+// so the checkpoint can only move past an unbroken prefix of completed ranges, not merely the
+// one that just finished. Advancing on the first shard to report, rather than the last, would
+// let a resume skip over data other shards hadn't sent yet.
+func (st *Stream) markRangeDone(kr keyRange) error {
+	if st.EmitCheckpoint == nil {
+		return nil
+	}
+	st.checkpointMu.Lock()
+	defer st.checkpointMu.Unlock()
+
+	idx, ok := st.boundaryIdx[string(kr.right)]
+	if !ok {
+		return nil
+	}
+	key := string(kr.right)
+	st.rangeShardsPending[key]--
+	if st.rangeShardsPending[key] > 0 {
+		return nil
+	}
+	st.completed[idx] = true
+
+	var boundary []byte
+	for st.checkpointCur < len(st.completed) && st.completed[st.checkpointCur] {
+		// The final keyRange has no right boundary, so it must not discard the boundary of the
+		// ranges completed before it.
+		if b := st.rightBounds[st.checkpointCur]; len(b) > 0 {
+			boundary = b
+		}
+		st.checkpointCur++
+	}
+	if boundary == nil {
+		return nil
+	}
+	cp := checkpoint{boundary: boundary, readTs: st.readTs}
+	return st.EmitCheckpoint(cp.encode())
+}
+
+// shardFor returns the index into st.kvChans that key routes to. With the default NumShards of
+// 1 (or no Shard func configured), everything routes to shard 0, preserving the original
+// single-Send behavior.
+func (st *Stream) shardFor(key []byte) int {
+	if st.Shard == nil || len(st.kvChans) <= 1 {
+		return 0
+	}
+	idx := st.Shard(key) % len(st.kvChans)
+	if idx < 0 {
+		idx += len(st.kvChans)
+	}
+	return idx
+}
+
+// passesSinceVersion reports whether a key at version should survive Stream's SinceVersion
+// watermark. Kept separate from produceKVs' iteration loop, like shardFor, so it's unit-testable
+// without a DB.
+func passesSinceVersion(version, since uint64) bool {
+	return since == 0 || version > since
+}
+
+// produceKVs picks up ranges from rangeCh, generates KV lists and sends them to the kvChan of
+// whichever shard Stream.Shard routes each key to.
 func (st *Stream) produceKVs(ctx context.Context) error {
-	var size int
 	var txn *Txn
 	if st.readTs > 0 {
 		txn = st.db.NewTransactionAt(st.readTs, false)
@@ -136,7 +459,28 @@ func (st *Stream) produceKVs(ctx context.Context) error {
 		itr := txn.NewIterator(iterOpts)
 		defer itr.Close()
 
-		outList := new(pb.KVList)
+		outLists := make([]*pb.KVList, len(st.kvChans))
+		sizes := make([]int, len(st.kvChans))
+		touched := make([]bool, len(st.kvChans))
+		for i := range outLists {
+			outLists[i] = new(pb.KVList)
+		}
+
+		// flush pushes shardIdx's pending KVs, if any, onto its kvChan. ranges, when non-empty,
+		// rides along on this particular push and is only reported to markRangeDone by streamKVs
+		// once the push has actually been handed to Send -- not here, since this only means the
+		// data has been enqueued, not sent. Throttling also happens there, right before Send, so
+		// each byte/key is only debited from the rate limiters once.
+		flush := func(shardIdx int, ranges []keyRange) error {
+			if len(outLists[shardIdx].Kv) == 0 && len(ranges) == 0 {
+				return nil
+			}
+			st.kvChans[shardIdx] <- &kvBatch{list: outLists[shardIdx], ranges: ranges}
+			outLists[shardIdx] = new(pb.KVList)
+			sizes[shardIdx] = 0
+			return nil
+		}
+
 		var prevKey []byte
 		for itr.Seek(kr.left); itr.Valid(); {
 			// it.Valid would only return true for keys with the provided Prefix in iterOpts.
@@ -155,25 +499,65 @@ func (st *Stream) produceKVs(ctx context.Context) error {
 			if st.ChooseKey != nil && !st.ChooseKey(item) {
 				continue
 			}
+			// The highest version of a key is seen first. If it's already at or below the
+			// watermark, every earlier version is too, so skip the whole key.
+			if !passesSinceVersion(item.Version(), st.SinceVersion) {
+				continue
+			}
 
 			// Now convert to key value.
 			list, err := st.KeyToList(item.KeyCopy(nil), itr)
 			if err != nil {
 				return err
 			}
+			// KeyToList can also return older versions of the key; drop those at or below the
+			// watermark too.
+			if list != nil && st.SinceVersion > 0 {
+				kept := list.Kv[:0]
+				for _, kv := range list.Kv {
+					if passesSinceVersion(kv.Version, st.SinceVersion) {
+						kept = append(kept, kv)
+					}
+				}
+				list.Kv = kept
+			}
 			if list == nil || len(list.Kv) == 0 {
 				continue
 			}
-			outList.Kv = append(outList.Kv, list.Kv...)
-			size += list.Size()
-			if size >= pageSize {
-				st.kvChan <- outList
-				outList = new(pb.KVList)
-				size = 0
+			shardIdx := st.shardFor(item.Key())
+			touched[shardIdx] = true
+			outLists[shardIdx].Kv = append(outLists[shardIdx].Kv, list.Kv...)
+			sizes[shardIdx] += list.Size()
+			if sizes[shardIdx] >= pageSize {
+				if err := flush(shardIdx, nil); err != nil {
+					return err
+				}
+			}
+		}
+		// kr is now fully produced. Attach it to the closing flush of every shard that actually
+		// carried data for it, so markRangeDone only fires once that shard's last Send for kr
+		// succeeds. Shards kr never touched owe it nothing. registerRangeShards must run before
+		// any of those closing flushes reach their shard's kvChan, since streamKVs on another
+		// goroutine could otherwise drain and Send one before the pending count is even set.
+		touchedCount := 0
+		for _, t := range touched {
+			if t {
+				touchedCount++
 			}
 		}
-		if len(outList.Kv) > 0 {
-			st.kvChan <- outList
+		if touchedCount == 0 {
+			// Nothing was ever sent for kr (e.g. ChooseKey/SinceVersion filtered it out
+			// entirely), so there's no Send to wait on -- mark it done right away.
+			return st.markRangeDone(kr)
+		}
+		st.registerRangeShards(kr, touchedCount)
+		for shardIdx := range outLists {
+			if !touched[shardIdx] {
+				continue
+			}
+			if err := flush(shardIdx, []keyRange{kr}); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
@@ -194,42 +578,64 @@ func (st *Stream) produceKVs(ctx context.Context) error {
 	}
 }
 
-func (st *Stream) streamKVs(ctx context.Context, logPrefix string) error {
+// streamKVs drains the kvChan belonging to shardIdx and hands batches to Send. With the default
+// NumShards of 1 there is exactly one of these goroutines, and Send's single-threaded-execution
+// invariant holds globally as before; with NumShards > 1, that invariant only holds per shard --
+// Send may be invoked concurrently by different shards' streamKVs goroutines.
+func (st *Stream) streamKVs(ctx context.Context, logPrefix string, shardIdx int) error {
 	var count int
 	var bytesSent uint64
+	kvChan := st.kvChans[shardIdx]
+	monitor := st.monitors[shardIdx]
+	shardPrefix := logPrefix
+	if len(st.kvChans) > 1 {
+		shardPrefix = fmt.Sprintf("%s[shard %d]", logPrefix, shardIdx)
+	}
 	t := time.NewTicker(time.Second)
 	defer t.Stop()
 	now := time.Now()
 
-	slurp := func(batch *pb.KVList) error {
+	slurp := func(batch *kvBatch) error {
 	loop:
 		for {
 			select {
-			case kvs, ok := <-st.kvChan:
+			case kvs, ok := <-kvChan:
 				if !ok {
 					break loop
 				}
 				y.AssertTrue(kvs != nil)
-				batch.Kv = append(batch.Kv, kvs.Kv...)
+				batch.list.Kv = append(batch.list.Kv, kvs.list.Kv...)
+				batch.ranges = append(batch.ranges, kvs.ranges...)
 			default:
 				break loop
 			}
 		}
-		sz := uint64(batch.Size())
+		sz := uint64(batch.list.Size())
+		if err := st.throttle(ctx, int(sz), len(batch.list.Kv)); err != nil {
+			return err
+		}
 		bytesSent += sz
-		count += len(batch.Kv)
+		count += len(batch.list.Kv)
 		t := time.Now()
-		if err := st.Send(batch); err != nil {
+		if err := st.Send(batch.list); err != nil {
 			return err
 		}
+		monitor.sample(bytesSent)
 		Infof("%s Created batch of size: %s in %s.\n",
-			logPrefix, humanize.Bytes(sz), time.Since(t))
+			shardPrefix, humanize.Bytes(sz), time.Since(t))
+		// Only now that Send has actually succeeded can the ranges riding on this batch be
+		// considered fully sent.
+		for _, kr := range batch.ranges {
+			if err := st.markRangeDone(kr); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 
 outer:
 	for {
-		var batch *pb.KVList
+		var batch *kvBatch
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -241,10 +647,12 @@ outer:
 				continue
 			}
 			speed := bytesSent / durSec
-			Infof("%s Time elapsed: %s, bytes sent: %s, speed: %s/sec\n",
-				logPrefix, y.FixedDuration(dur), humanize.Bytes(bytesSent), humanize.Bytes(speed))
+			_, avgRate, _ := st.Status()
+			Infof("%s Time elapsed: %s, bytes sent: %s, speed: %s/sec, avg: %s/sec\n",
+				shardPrefix, y.FixedDuration(dur), humanize.Bytes(bytesSent), humanize.Bytes(speed),
+				humanize.Bytes(uint64(avgRate)))
 
-		case kvs, ok := <-st.kvChan:
+		case kvs, ok := <-kvChan:
 			if !ok {
 				break outer
 			}
@@ -256,28 +664,63 @@ outer:
 		}
 	}
 
-	Infof("%s Sent %d keys\n", logPrefix, count)
+	Infof("%s Sent %d keys\n", shardPrefix, count)
 	return nil
 }
 
 // Orchestrate runs Stream. It picks up ranges from the SSTables, then runs numGo number of
-// goroutines to iterate over these ranges and batch up KVs in lists. It then runs a single
-// goroutine to pick these lists, batch them up further and send to Output.Send. Orchestrate also
-// spits logs out to Infof, using the logPrefix string provided.  Note that all calls to
-// Output.Send are serial. In case any of these steps encounter an error, Orchestrate would stop
-// execution and return that error. Orchestrate should only be called once on the same Stream
-// object.
+// goroutines to iterate over these ranges and batch up KVs in lists. It then runs one goroutine
+// per shard (see Shard and NumShards; a single shard by default) to pick these lists, batch them
+// up further and send to Output.Send. Orchestrate also spits logs out to Infof, using the
+// logPrefix string provided. With the default NumShards of 1, all calls to Output.Send are
+// serial; with more shards, Send may be called concurrently by different shards, though calls
+// within a single shard remain serial. In case any of these steps encounter an error, Orchestrate
+// stops execution and returns the first such error. Orchestrate should only be called once on the
+// same Stream object.
+//
+// If Follow is set, Orchestrate does not return once the initial snapshot has been sent; it
+// keeps running until ctx is done, forwarding newly committed entries above that snapshot; a
+// Send or subscription error ends it early and is returned.
 func (st *Stream) Orchestrate(ctx context.Context, numGo int, logPrefix string) error {
 	st.rangeCh = make(chan keyRange, 3) // Contains keys for posting lists.
 
-	// kvChan should only have a small capacity to ensure that we don't buffer up too much data if
-	// sending is slow. So, setting this to 3.
-	st.kvChan = make(chan *pb.KVList, 3)
+	if len(st.Since) > 0 {
+		// Adopt the checkpoint's readTs up front, before produceRanges and produceKVs start
+		// running concurrently below -- produceKVs reads st.readTs exactly once, when it opens
+		// its transaction, so setting it any later would race. This is what lets a resumed
+		// Stream read the same consistent snapshot the original run did, rather than whatever
+		// readTs the caller happened to pass to NewStreamAt this time around.
+		if resume := decodeCheckpoint(st.Since); resume.readTs > 0 {
+			st.readTs = resume.readTs
+		}
+	}
+
+	numShards := st.NumShards
+	if numShards <= 0 {
+		numShards = 1
+	}
+	// Each shard's kvChan should only have a small capacity to ensure that we don't buffer up
+	// too much data if sending is slow. So, setting this to 3.
+	st.kvChans = make([]chan *kvBatch, numShards)
+	for i := range st.kvChans {
+		st.kvChans[i] = make(chan *kvBatch, 3)
+	}
 
 	if st.KeyToList == nil {
 		st.KeyToList = st.ToList
 	}
 
+	st.monitors = make([]*Monitor, numShards)
+	for i := range st.monitors {
+		st.monitors[i] = newMonitor()
+	}
+	if st.MaxBytesPerSec > 0 {
+		st.byteLimiter = newRateLimiter(float64(st.MaxBytesPerSec))
+	}
+	if st.MaxKeysPerSec > 0 {
+		st.keyLimiter = newRateLimiter(float64(st.MaxKeysPerSec))
+	}
+
 	// Picks up ranges from Badger, and sends them to rangeCh.
 	go st.produceRanges(ctx)
 
@@ -287,7 +730,8 @@ func (st *Stream) Orchestrate(ctx context.Context, numGo int, logPrefix string)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			// Picks up ranges from rangeCh, generates KV lists, and sends them to kvChan.
+			// Picks up ranges from rangeCh, generates KV lists, and sends them to the right
+			// shard's kvChan.
 			if err := st.produceKVs(ctx); err != nil {
 				select {
 				case errCh <- err:
@@ -297,14 +741,27 @@ func (st *Stream) Orchestrate(ctx context.Context, numGo int, logPrefix string)
 		}()
 	}
 
-	// Pick up key-values from kvChan and send to stream.
-	kvErr := make(chan error, 1)
-	go func() {
-		// Picks up KV lists from kvChan, and sends them to Output.
-		kvErr <- st.streamKVs(ctx, logPrefix)
-	}()
-	wg.Wait()        // Wait for produceKVs to be over.
-	close(st.kvChan) // Now we can close kvChan.
+	// Run one streamKVs goroutine per shard, each picking up key-values from its own kvChan and
+	// sending them to Output.
+	shardErrCh := make(chan error, 1)
+	var swg sync.WaitGroup
+	for i := 0; i < numShards; i++ {
+		swg.Add(1)
+		go func(shardIdx int) {
+			defer swg.Done()
+			if err := st.streamKVs(ctx, logPrefix, shardIdx); err != nil {
+				select {
+				case shardErrCh <- err:
+				default:
+				}
+			}
+		}(i)
+	}
+	wg.Wait() // Wait for produceKVs to be over.
+	for _, ch := range st.kvChans {
+		close(ch) // Now we can close every shard's kvChan.
+	}
+	swg.Wait() // Wait for every shard's streamKVs to be over.
 
 	select {
 	case err := <-errCh: // Check error from produceKVs.
@@ -312,8 +769,34 @@ func (st *Stream) Orchestrate(ctx context.Context, numGo int, logPrefix string)
 	default:
 	}
 
-	// Wait for key streaming to be over.
-	if err := <-kvErr; err != nil {
+	select {
+	case err := <-shardErrCh: // Check error from streamKVs, from whichever shard hit it first.
+		return err
+	default:
+	}
+
+	if st.Follow {
+		// The SinceVersion snapshot is done. Piggyback on the DB's existing subscribe/publisher
+		// machinery to keep turning newly committed entries into KVLists and pushing them
+		// through the same Send used above, so Stream behaves as a single CDC feed. Subscribe's
+		// callback has no error return, so a Send failure is captured in sendErr and the
+		// subscription itself is cancelled to unwind Subscribe; the captured error, not
+		// Subscribe's own return value, is what Orchestrate reports.
+		followCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var sendErr error
+		err := st.db.Subscribe(followCtx, func(kvs *pb.KVList) {
+			if sendErr != nil {
+				return
+			}
+			if err := st.Send(kvs); err != nil {
+				sendErr = err
+				cancel()
+			}
+		}, st.Prefix)
+		if sendErr != nil {
+			return sendErr
+		}
 		return err
 	}
 	return nil
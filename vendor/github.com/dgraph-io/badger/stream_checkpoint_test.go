@@ -0,0 +1,109 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package badger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCheckpointStream builds a Stream wired up with n synthetic keyRanges, as if
+// produceRanges had already run, so markRangeDone's bookkeeping can be exercised directly
+// without a real DB.
+func newTestCheckpointStream(n int, emit func(token []byte) error) *Stream {
+	st := &Stream{EmitCheckpoint: emit}
+	st.rightBounds = make([][]byte, n)
+	st.completed = make([]bool, n)
+	st.boundaryIdx = make(map[string]int, n)
+	st.rangeShardsPending = make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		right := []byte{byte('a' + i)}
+		st.rightBounds[i] = right
+		st.boundaryIdx[string(right)] = i
+	}
+	return st
+}
+
+func krFor(st *Stream, idx int) keyRange {
+	return keyRange{right: st.rightBounds[idx]}
+}
+
+func TestMarkRangeDoneAdvancesCheckpointOnlyOverUnbrokenPrefix(t *testing.T) {
+	var tokens [][]byte
+	st := newTestCheckpointStream(4, func(token []byte) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	// Range 1 finishes before range 0: the checkpoint cursor must not move past range 0 yet,
+	// since range completion is out of order and the boundary can only advance over a prefix
+	// that is entirely done.
+	require.NoError(t, st.markRangeDone(krFor(st, 1)))
+	require.Empty(t, tokens)
+
+	// Range 0 finishes: now ranges 0 and 1 are both done, so the checkpoint should jump past
+	// both in one shot, landing on range 1's boundary.
+	require.NoError(t, st.markRangeDone(krFor(st, 0)))
+	require.Len(t, tokens, 1)
+	require.Equal(t, decodeCheckpoint(tokens[0]).boundary, st.rightBounds[1])
+
+	// Range 3 finishes out of order again; still nothing new since range 2 is still pending.
+	require.NoError(t, st.markRangeDone(krFor(st, 3)))
+	require.Len(t, tokens, 1)
+
+	// Range 2 finishes, unblocking the run through range 3.
+	require.NoError(t, st.markRangeDone(krFor(st, 2)))
+	require.Len(t, tokens, 2)
+	require.Equal(t, decodeCheckpoint(tokens[1]).boundary, st.rightBounds[3])
+}
+
+// TestMarkRangeDoneWaitsForAllShardsBeforeAdvancing guards against the checkpoint advancing past
+// a range as soon as the first of several shards that split it reports in, which would let a
+// resume after a crash skip the slices other, slower shards hadn't sent yet.
+func TestMarkRangeDoneWaitsForAllShardsBeforeAdvancing(t *testing.T) {
+	var tokens [][]byte
+	st := newTestCheckpointStream(2, func(token []byte) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	kr := krFor(st, 0)
+	st.registerRangeShards(kr, 3)
+
+	require.NoError(t, st.markRangeDone(kr))
+	require.Empty(t, tokens, "one of three shards reporting in must not advance the checkpoint")
+	require.NoError(t, st.markRangeDone(kr))
+	require.Empty(t, tokens, "two of three shards reporting in must not advance the checkpoint")
+
+	require.NoError(t, st.markRangeDone(kr))
+	require.Len(t, tokens, 1, "the third and last shard reporting in must advance the checkpoint")
+	require.Equal(t, decodeCheckpoint(tokens[0]).boundary, st.rightBounds[0])
+}
+
+func TestMarkRangeDoneNoopWithoutEmitCheckpoint(t *testing.T) {
+	st := newTestCheckpointStream(2, nil)
+	require.NoError(t, st.markRangeDone(krFor(st, 0)))
+	require.NoError(t, st.markRangeDone(krFor(st, 1)))
+}
+
+func TestCheckpointEncodeDecodeRoundTrip(t *testing.T) {
+	cp := checkpoint{boundary: []byte("some/key/boundary"), readTs: 12345}
+	got := decodeCheckpoint(cp.encode())
+	require.Equal(t, cp.readTs, got.readTs)
+	require.Equal(t, cp.boundary, got.boundary)
+}
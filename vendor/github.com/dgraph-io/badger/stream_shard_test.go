@@ -0,0 +1,52 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package badger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardForDefaultsToZeroWithoutShardFunc(t *testing.T) {
+	st := &Stream{kvChans: make([]chan *kvBatch, 4)}
+	require.Equal(t, 0, st.shardFor([]byte("anything")))
+}
+
+func TestShardForSingleShardAlwaysZero(t *testing.T) {
+	st := &Stream{
+		Shard:   func(key []byte) int { return 7 },
+		kvChans: make([]chan *kvBatch, 1),
+	}
+	require.Equal(t, 0, st.shardFor([]byte("x")))
+}
+
+func TestShardForAppliesModulo(t *testing.T) {
+	st := &Stream{
+		Shard:   func(key []byte) int { return 9 },
+		kvChans: make([]chan *kvBatch, 4),
+	}
+	require.Equal(t, 1, st.shardFor([]byte("x")))
+}
+
+func TestShardForWrapsNegativeIndex(t *testing.T) {
+	st := &Stream{
+		Shard:   func(key []byte) int { return -1 },
+		kvChans: make([]chan *kvBatch, 4),
+	}
+	require.Equal(t, 3, st.shardFor([]byte("x")))
+}
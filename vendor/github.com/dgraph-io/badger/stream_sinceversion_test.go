@@ -0,0 +1,30 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package badger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassesSinceVersion(t *testing.T) {
+	require.True(t, passesSinceVersion(5, 0), "SinceVersion left at zero must let everything through")
+	require.False(t, passesSinceVersion(5, 5), "at the watermark must be filtered out")
+	require.False(t, passesSinceVersion(4, 5), "below the watermark must be filtered out")
+	require.True(t, passesSinceVersion(6, 5), "strictly above the watermark must survive")
+}
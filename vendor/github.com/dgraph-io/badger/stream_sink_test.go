@@ -0,0 +1,66 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package badger
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/dgraph-io/badger/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkRoundTrip(t *testing.T) {
+	for _, format := range []SinkFormat{SinkRaw, SinkGzip, SinkZstd} {
+		format := format
+		t.Run(fmt.Sprintf("format=%d", format), func(t *testing.T) {
+			var buf bytes.Buffer
+			sink, closeSink, err := wrapSink(&buf, format)
+			require.NoError(t, err)
+
+			lists := []*pb.KVList{
+				{Kv: []*pb.KV{{Key: []byte("foo"), Value: []byte("bar"), Version: 1}}},
+				{Kv: []*pb.KV{{Key: []byte("baz"), Value: []byte("quux"), Version: 2}}},
+			}
+			for _, list := range lists {
+				require.NoError(t, writeKVList(sink, list))
+			}
+			require.NoError(t, closeSink())
+
+			src, closeSrc, err := wrapSource(&buf, format)
+			require.NoError(t, err)
+			defer closeSrc()
+
+			for _, want := range lists {
+				got, err := readKVList(src)
+				require.NoError(t, err)
+				require.Equal(t, want.Kv, got.Kv)
+			}
+		})
+	}
+}
+
+func TestWrapSinkUnknownFormat(t *testing.T) {
+	_, _, err := wrapSink(&bytes.Buffer{}, SinkFormat(99))
+	require.Error(t, err)
+}
+
+func TestWrapSourceUnknownFormat(t *testing.T) {
+	_, _, err := wrapSource(&bytes.Buffer{}, SinkFormat(99))
+	require.Error(t, err)
+}